@@ -9,10 +9,163 @@ Package config 설정 패키지
 */
 package config
 
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
 const (
 	ModuleName = "openkms"
 )
 
 const (
 	PidFilePath = "var/" + ModuleName + ".pid"
+	LogFilePath = "log/" + ModuleName + ".log"
 )
+
+// DefaultConfigPath 기본 설정 파일 경로
+const DefaultConfigPath = "etc/" + ModuleName + ".toml"
+
+// LogConfig 로그 설정 정보 구조체
+type LogConfig struct {
+	Level      string `toml:"level"`       // 로그 레벨(debug, info, warn, error)
+	FilePath   string `toml:"file_path"`   // 로그 파일 경로
+	MaxSize    int    `toml:"max_size"`    // 로그 파일 최대 크기(MB)
+	MaxBackups int    `toml:"max_backups"` // 보관할 백업 파일 수
+	MaxAge     int    `toml:"max_age"`     // 보관할 최대 일수
+	Compress   bool   `toml:"compress"`    // 백업 파일 압축 여부
+
+	LokiEnable bool   `toml:"loki_enable"` // Loki 전송 사용 여부
+	LokiHost   string `toml:"loki_host"`   // Loki 서버 호스트
+	LokiPort   int    `toml:"loki_port"`   // Loki 서버 포트
+	LokiJob    string `toml:"loki_job"`    // Loki 스트림 job 레이블
+	LokiSource string `toml:"loki_source"` // Loki 스트림 source 레이블
+}
+
+// DaemonConfig 데몬 설정 정보 구조체
+type DaemonConfig struct {
+	PidFilePath string `toml:"pid_file_path"` // PID 파일 경로
+	WorkDir     string `toml:"work_dir"`      // 작업 디렉터리
+	User        string `toml:"user"`          // 데몬 실행 사용자
+	Group       string `toml:"group"`         // 데몬 실행 그룹
+}
+
+// Config 설정 정보 구조체
+type Config struct {
+	Log    LogConfig    `toml:"log"`
+	Daemon DaemonConfig `toml:"daemon"`
+}
+
+// current 현재 적용중인 설정(atomic.Pointer로 Reload 시 원자적으로 교체)
+var current atomic.Pointer[Config]
+
+// configPath 마지막으로 로드에 성공한 설정 파일 경로(Reload에서 재사용)
+//
+// SIGHUP 핸들러와 제어 소켓의 "reload" 명령이 각각 별도의 고루틴에서 동시에
+// Reload를 호출할 수 있으므로 atomic.Pointer로 보호한다.
+var configPath atomic.Pointer[string]
+
+// Load 설정 파일을 읽어 전역 설정으로 적용
+//
+// Parameters:
+//   - path: 설정 파일 경로
+//
+// Returns:
+//   - *Config: 로드된 설정 정보
+//   - error: 성공(nil), 실패(error)
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %s", err)
+	}
+
+	cfg := defaultConfig()
+	if err := toml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %s", err)
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	configPath.Store(&path)
+	current.Store(cfg)
+
+	return cfg, nil
+}
+
+// Reload 마지막으로 로드된 설정 파일을 다시 읽어 원자적으로 교체
+//
+// Returns:
+//   - *Config: 새로 로드된 설정 정보
+//   - error: 성공(nil), 실패(error)
+func Reload() (*Config, error) {
+	path := configPath.Load()
+	if path == nil || *path == "" {
+		return nil, fmt.Errorf("config has not been loaded yet")
+	}
+
+	return Load(*path)
+}
+
+// Get 현재 적용중인 설정 반환(Load가 호출되지 않았으면 nil)
+//
+// Returns:
+//   - *Config: 현재 설정 정보
+func Get() *Config {
+	return current.Load()
+}
+
+// defaultConfig 기본값이 채워진 설정 정보 생성
+//
+// Returns:
+//   - *Config: 기본 설정 정보
+func defaultConfig() *Config {
+	return &Config{
+		Log: LogConfig{
+			Level:      "info",
+			FilePath:   LogFilePath,
+			MaxSize:    100,
+			MaxBackups: 10,
+			MaxAge:     30,
+			Compress:   true,
+		},
+		Daemon: DaemonConfig{
+			PidFilePath: PidFilePath,
+		},
+	}
+}
+
+// validate 설정 값 유효성 검사
+//
+// Returns:
+//   - error: 성공(nil), 실패(error)
+func (c *Config) validate() error {
+	switch c.Log.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		return fmt.Errorf("log.level must be one of debug/info/warn/error, got %q", c.Log.Level)
+	}
+
+	if c.Log.FilePath == "" {
+		return fmt.Errorf("log.file_path must not be empty")
+	}
+
+	if c.Daemon.PidFilePath == "" {
+		return fmt.Errorf("daemon.pid_file_path must not be empty")
+	}
+
+	if c.Log.LokiEnable {
+		if c.Log.LokiHost == "" {
+			return fmt.Errorf("log.loki_host must not be empty when log.loki_enable is true")
+		}
+		if c.Log.LokiPort <= 0 {
+			return fmt.Errorf("log.loki_port must be a positive port number when log.loki_enable is true")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+//go:build linux
+
+// Copyright (c) 2024 Generic API Server All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package control 실행중인 데몬을 제어하기 위한 유닉스 도메인 소켓 서버 패키지
+*/
+package control
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"openkms/utils/log"
+	"os"
+	"time"
+)
+
+// SocketPath 제어 소켓 기본 경로
+const SocketPath = "var/openkms.sock"
+
+// command 제어 소켓으로 수신하는 명령(개행으로 구분된 JSON) 구조체
+type command struct {
+	Cmd   string `json:"cmd"`
+	Level string `json:"level"`
+}
+
+// response 제어 소켓 명령 처리 결과 응답 구조체
+type response struct {
+	OK      bool   `json:"ok"`
+	Message string `json:"message,omitempty"`
+	Pid     int    `json:"pid,omitempty"`
+	Uptime  string `json:"uptime,omitempty"`
+}
+
+// Server 유닉스 도메인 소켓 기반 제어 서버
+type Server struct {
+	listener  net.Listener
+	startTime time.Time
+	reload    func() error
+}
+
+// NewServer 제어 서버를 생성하고 연결 수락을 시작
+//
+// Parameters:
+//   - reload: "reload" 명령 수신 시 호출할 콜백(설정 재적재)
+//
+// Returns:
+//   - *Server: 생성된 제어 서버
+//   - error: 성공(nil), 실패(error)
+func NewServer(reload func() error) (*Server, error) {
+	os.Remove(SocketPath) // 비정상 종료로 남아있을 수 있는 이전 소켓 파일 정리
+
+	listener, err := net.Listen("unix", SocketPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on control socket: %s", err)
+	}
+
+	if err := os.Chmod(SocketPath, 0600); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to set control socket permission: %s", err)
+	}
+
+	server := &Server{
+		listener:  listener,
+		startTime: time.Now(),
+		reload:    reload,
+	}
+
+	go server.serve()
+
+	return server, nil
+}
+
+// Close 제어 서버 종료(소켓 파일도 함께 정리)
+//
+// Returns:
+//   - error: 성공(nil), 실패(error)
+func (s *Server) Close() error {
+	err := s.listener.Close()
+	os.Remove(SocketPath)
+	return err
+}
+
+// serve 연결 수락 루프
+func (s *Server) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return // 리스너가 닫히면 루프 종료
+		}
+
+		go s.handle(conn)
+	}
+}
+
+// handle 한 연결에서 개행으로 구분된 JSON 명령을 읽어 처리하고 결과를 응답
+//
+// Parameters:
+//   - conn: 수락된 연결
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+
+	scanner := bufio.NewScanner(conn)
+	encoder := json.NewEncoder(conn)
+
+	for scanner.Scan() {
+		var cmd command
+		if err := json.Unmarshal(scanner.Bytes(), &cmd); err != nil {
+			encoder.Encode(response{OK: false, Message: fmt.Sprintf("invalid command: %s", err)})
+			continue
+		}
+
+		encoder.Encode(s.dispatch(cmd))
+	}
+}
+
+// dispatch 명령을 처리하여 응답 생성
+//
+// Parameters:
+//   - cmd: 수신한 명령
+//
+// Returns:
+//   - response: 처리 결과
+func (s *Server) dispatch(cmd command) response {
+	switch cmd.Cmd {
+	case "status":
+		return response{
+			OK:      true,
+			Message: "running",
+			Pid:     os.Getpid(),
+			Uptime:  time.Since(s.startTime).String(),
+		}
+	case "loglevel":
+		if err := log.SetLevel(cmd.Level); err != nil {
+			return response{OK: false, Message: err.Error()}
+		}
+		return response{OK: true, Message: fmt.Sprintf("log level set to %s", cmd.Level)}
+	case "reload":
+		if s.reload != nil {
+			if err := s.reload(); err != nil {
+				return response{OK: false, Message: err.Error()}
+			}
+		}
+		return response{OK: true, Message: "reloaded"}
+	case "stats":
+		return response{
+			OK:      true,
+			Message: "ok",
+			Pid:     os.Getpid(),
+			Uptime:  time.Since(s.startTime).String(),
+		}
+	default:
+		return response{OK: false, Message: fmt.Sprintf("unknown command: %s", cmd.Cmd)}
+	}
+}
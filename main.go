@@ -10,29 +10,42 @@ Package main 메인 패키지
 package main
 
 import (
+	"bufio"
 	"flag"
 	"fmt"
+	"net"
 	"openkms/config"
+	"openkms/control"
 	"openkms/utils/file"
 	"openkms/utils/log"
 	"openkms/utils/process"
 	"os"
 	"os/signal"
+	"os/user"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"syscall"
 )
 
 // options 명령행 옵션 정보 구조체
 type options struct {
-	version bool
-	help    bool
+	version    bool
+	help       bool
+	configPath string
 }
 
+// daemonContext 데몬 프로세스 실행 컨텍스트
+var daemonContext *process.Context
+
+// controlServer 런타임 제어용 유닉스 도메인 소켓 서버
+var controlServer *control.Server
+
 // setOptions 옵션 값 설정
 func (o *options) setOptions() {
 	flag.BoolVar(&o.version, "v", false, "Print version")
 	flag.BoolVar(&o.help, "h", false, "Print help")
+	flag.StringVar(&o.configPath, "c", config.DefaultConfigPath, "Config file path")
 }
 
 // getVersion 버전 정보 출력
@@ -47,7 +60,7 @@ func (o *options) getVersion() string {
 func (o *options) usage() {
 	fmt.Println(o.getVersion())
 	fmt.Println("Build Date:", BuildDate)
-	fmt.Println("Command: start | stop")
+	fmt.Println("Command: start | stop | status | reload")
 	flag.Usage()
 }
 
@@ -75,7 +88,16 @@ func main() {
 		os.Exit(0)
 	}
 
-	flag.Parse() // 명령행 옵션 파싱
+	// 명령어(start/stop/status/reload) 없이 -v, -h 등의 옵션만 전달된 경우
+	if strings.HasPrefix(os.Args[1], "-") {
+		flag.Parse()
+		option.processOption()
+		option.usage()
+		os.Exit(0)
+	}
+
+	command := os.Args[1]
+	flag.CommandLine.Parse(os.Args[2:]) // 명령어 뒤에 위치한 옵션(-c 등) 파싱
 
 	// 작업 경로를 현재 실행 파일의 경로로 변경
 	if err := changeWorkDir(); err != nil {
@@ -85,48 +107,165 @@ func main() {
 
 	option.processOption() // 명령행 옵션 처리
 
+	// 설정 파일 로드
+	if _, err := config.Load(option.configPath); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	ctx, err := newDaemonContext()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	daemonContext = ctx
+
 	// 동작 명령어 체크
-	switch os.Args[1] {
+	switch command {
 	case "start":
-		// 이미 동작중인 프로세스가 존재하는지 확인
-		if isProcessRunWithPidFile(config.PidFilePath) {
-			fmt.Println("There is already a working process")
-			os.Exit(0)
-		}
+		startProcess()
 	case "stop":
-		// 프로세스 종료 시그널(SIGTERM) 전송
-		pid, err := stopProcess(config.PidFilePath)
+		pid, err := stopProcess(config.Get().Daemon.PidFilePath)
 		if err != nil {
 			fmt.Println(err)
 		} else if pid != 0 {
 			fmt.Printf("Stop %s process (pid: %d)\n", config.ModuleName, pid)
 		}
 		os.Exit(0)
+	case "status":
+		printStatus()
+		os.Exit(0)
+	case "reload":
+		if err := sendReloadSignal(config.Get().Daemon.PidFilePath); err != nil {
+			fmt.Println(err)
+		}
+		os.Exit(0)
 	default:
 		option.usage()
 		os.Exit(0)
 	}
+}
 
-	sigChan := make(chan os.Signal, 1)
-	stopChan := make(chan bool)
+// newDaemonContext 설정 정보를 반영한 데몬 프로세스 실행 컨텍스트 생성
+//
+// Returns:
+//   - *process.Context: 데몬 프로세스 실행 컨텍스트
+//   - error: 성공(nil), 실패(error)
+func newDaemonContext() (*process.Context, error) {
+	cfg := config.Get()
 
-	setupSignal(sigChan) // 시그널 설정
+	credential, err := lookupCredential(cfg.Daemon.User, cfg.Daemon.Group)
+	if err != nil {
+		return nil, err
+	}
 
-	// 환경 변수를 체크하여 데몬 프로세스인지 확인
-	if os.Getenv("DAEMON") != "true" {
-		// 프로세스 데몬화
-		// 데몬화 성공 시 함수 내부에서 프로세스 종료
-		err := process.Daemonize()
-		// 프로세스 데몬화 실패
-		fmt.Println(err)
+	return &process.Context{
+		PidFileName: cfg.Daemon.PidFilePath,
+		PidFilePerm: 0644,
+		LogFileName: cfg.Log.FilePath,
+		LogFilePerm: 0640,
+		WorkDir:     cfg.Daemon.WorkDir,
+		Credential:  credential,
+	}, nil
+}
+
+// lookupCredential daemon.user/daemon.group 설정값을 uid/gid로 변환
+//
+// 두 값 모두 비어 있으면 권한을 낮추지 않는다(nil 반환).
+//
+// Parameters:
+//   - username: 데몬 실행 사용자명(비어 있으면 현재 사용자 유지)
+//   - groupname: 데몬 실행 그룹명(비어 있으면 사용자의 기본 그룹 사용)
+//
+// Returns:
+//   - *syscall.Credential: 변환된 uid/gid 정보(nil이면 변경하지 않음)
+//   - error: 성공(nil), 실패(error)
+func lookupCredential(username, groupname string) (*syscall.Credential, error) {
+	if username == "" && groupname == "" {
+		return nil, nil
+	}
+
+	// username이 비어 있으면 uid는 현재 프로세스의 uid를 유지한다(0으로 두면
+	// daemon.group만 설정했을 때 의도치 않게 root로 setuid 되어 버린다).
+	uid, gid := os.Getuid(), 0
+
+	if username != "" {
+		u, err := user.Lookup(username)
+		if err != nil {
+			return nil, fmt.Errorf("failed to lookup daemon.user %q: %s", username, err)
+		}
+
+		uid, err = strconv.Atoi(u.Uid)
+		if err != nil {
+			return nil, fmt.Errorf("invalid uid for daemon.user %q: %s", username, err)
+		}
+
+		gid, err = strconv.Atoi(u.Gid)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gid for daemon.user %q: %s", username, err)
+		}
+	}
+
+	if groupname != "" {
+		g, err := user.LookupGroup(groupname)
+		if err != nil {
+			return nil, fmt.Errorf("failed to lookup daemon.group %q: %s", groupname, err)
+		}
+
+		gid, err = strconv.Atoi(g.Gid)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gid for daemon.group %q: %s", groupname, err)
+		}
+	}
+
+	return &syscall.Credential{Uid: uint32(uid), Gid: uint32(gid)}, nil
+}
+
+// startProcess 프로세스를 데몬화하여 시작
+func startProcess() {
+	// Reborn()이 PID 파일을 열고(부모) 표준 출력/에러를 로그 파일로 재지정하므로(자식),
+	// initialization()의 디렉터리 생성을 기다리지 않고 미리 상위 디렉터리를 만들어 둔다.
+	file.MakeDirectory(filepath.Dir(daemonContext.PidFileName))
+	file.MakeDirectory(filepath.Dir(daemonContext.LogFileName))
+
+	// 프로세스 데몬화
+	// 부모 프로세스에서는 자식 프로세스 생성 후 반환, 자식 프로세스에서는 nil 반환
+	child, err := daemonContext.Reborn()
+	if err != nil {
+		if err == process.ErrAlreadyRunning {
+			fmt.Println("There is already a working process")
+		} else {
+			fmt.Println(err)
+		}
 		os.Exit(1)
 	}
 
-	// 종료 시그널 처리
+	// 부모 프로세스는 자식 프로세스 생성 확인 후 종료
+	if child != nil {
+		return
+	}
+	defer daemonContext.Release() // PID 파일 잠금 해제 및 삭제
+
+	sigChan := make(chan os.Signal, 1)
+	stopChan := make(chan bool)
+
+	setupSignal(sigChan) // 시그널 설정
+
+	// 종료/재적재 시그널 처리
 	go func() {
-		sig := <-sigChan
-		log.LogInfo("Receive SIGNAL: %d", sig)
-		stopChan <- true
+		for sig := range sigChan {
+			switch sig {
+			case syscall.SIGHUP:
+				log.LogInfo("Receive SIGNAL: SIGHUP, reloading configuration")
+				if err := reloadConfig(); err != nil {
+					log.LogWarn("%s", err)
+				}
+			default:
+				log.LogInfo("Receive SIGNAL: %d", sig)
+				stopChan <- true
+				return
+			}
+		}
 	}()
 
 	initialization() // 초기화
@@ -134,14 +273,71 @@ func main() {
 		finalization() // 종료 전 작업 정리
 	}()
 
-	// 데몬 프로세스인 경우 PID를 파일에 기록
-	err := file.WriteTextFile[int](config.PidFilePath, os.Getpid())
-	if err != nil {
-		log.LogWarn("%s", err)
+	<-stopChan // 종료 대기
+}
+
+// reloadConfig 설정 파일을 다시 읽어 로거에 반영(SIGHUP, control "reload" 명령 공용 처리)
+//
+// Returns:
+//   - error: 성공(nil), 실패(error)
+func reloadConfig() error {
+	if _, err := config.Reload(); err != nil {
+		return fmt.Errorf("failed to reload config: %s", err)
+	}
+
+	log.ReloadConfig()
+
+	return nil
+}
+
+// printStatus 데몬 동작 상태 출력
+//
+// 제어 소켓에 접속해 상태를 조회하고, 접속할 수 없으면 PID 파일을 근거로 판단한다.
+func printStatus() {
+	if conn, err := net.Dial("unix", control.SocketPath); err == nil {
+		defer conn.Close()
+
+		fmt.Fprintln(conn, `{"cmd":"status"}`)
+
+		scanner := bufio.NewScanner(conn)
+		if scanner.Scan() {
+			fmt.Println(scanner.Text())
+			return
+		}
+	}
+
+	pid, err := process.ReadPidFile(config.Get().Daemon.PidFilePath)
+	if err != nil || !process.IsProcessRunning(pid) {
+		fmt.Println("stopped")
 		return
 	}
 
-	<-stopChan // 종료 대기
+	fmt.Printf("running (pid: %d)\n", pid)
+}
+
+// sendReloadSignal PID 파일에 기록된 PID로 설정 재적재 시그널(SIGHUP)을 전송
+//
+// Parameters:
+//   - pidFilePath: PID 파일 경로
+//
+// Returns:
+//   - error: 성공(nil), 실패(error)
+func sendReloadSignal(pidFilePath string) error {
+	pid, err := process.ReadPidFile(pidFilePath)
+	if err != nil || !process.IsProcessRunning(pid) {
+		return fmt.Errorf("there is no working process")
+	}
+
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return fmt.Errorf("failed to find process (pid: %d): %s", pid, err)
+	}
+
+	if err := proc.Signal(syscall.SIGHUP); err != nil {
+		return fmt.Errorf("failed to send signal (pid: %d): %s", pid, err)
+	}
+
+	return nil
 }
 
 // changeWorkDir 작업 경로를 현재 실행 파일의 경로로 변경
@@ -166,63 +362,38 @@ func changeWorkDir() error {
 	return nil
 }
 
-// isProcessRunWithPidFile 파일에서 PID를 읽고, 해당 PID를 가진 프로세스가 동작 중인지 확인
+// stopProcess PID 파일에 기록된 PID로 프로세스 종료 시그널(SIGTERM)을 전송
 //
 // Parameters:
 //   - pidFilePath: PID 파일 경로
 //
 // Returns:
-//   - bool: 동작중(true), 미동작(false)
-func isProcessRunWithPidFile(pidFilePath string) bool {
-	// PID 파일 읽기
-	pidBytes, err := os.ReadFile(pidFilePath)
-	if err != nil {
-		return false
-	}
-
-	// 파일에서 읽은 PID를 정수로 변환
-	pid, err := strconv.Atoi(string(pidBytes))
-	if err != nil {
-		return false
-	}
-
-	// 프로세스가 동작중인지 확인
-	return process.IsProcessRunning(pid)
-}
-
+//   - int: 종료 시그널을 전송한 PID
+//   - error: 성공(nil), 실패(error)
 func stopProcess(pidFilePath string) (int, error) {
 	// PID 파일 읽기
-	pidBytes, err := os.ReadFile(pidFilePath)
-	if err != nil {
-		return 0, nil
-	}
-
-	// 파일에서 읽은 PID를 정수로 변환
-	pid, err := strconv.Atoi(string(pidBytes))
+	pid, err := process.ReadPidFile(pidFilePath)
 	if err != nil {
 		return 0, nil
 	}
 
 	// 프로세스가 존재하는지 확인
-	process, err := os.FindProcess(pid)
+	proc, err := os.FindProcess(pid)
 	if err != nil {
 		return 0, nil
 	}
 
-	// 시그널 0을 보내 실제로 프로세스가 동작중인지 확인
-	err = process.Signal(syscall.Signal(0))
-	if err != nil {
+	// 프로세스가 동작중이지 않으면 PID 파일만 정리
+	if !process.IsProcessRunning(pid) {
+		os.Remove(pidFilePath)
 		return 0, nil
 	}
 
 	// SIGTERM 시그널을 보내 프로세스 종료
-	err = process.Signal(syscall.Signal(syscall.SIGTERM))
-	if err != nil {
+	if err := proc.Signal(syscall.Signal(syscall.SIGTERM)); err != nil {
 		return 0, fmt.Errorf("failed to send signal (pid: %d): %s", pid, err)
 	}
 
-	os.Remove(pidFilePath) // PID 파일 삭제
-
 	return pid, nil
 }
 
@@ -230,11 +401,29 @@ func stopProcess(pidFilePath string) (int, error) {
 func initialization() {
 	file.MakeDirectory("var") // var 디렉터리 생성
 	file.MakeDirectory("log") // log 디렉터리 생성
-	log.InitLogger()          // 로거 초기화
+
+	// panic, 세그폴트 등 raw stderr로 출력되는 크래시를 파일에 보존
+	if err := log.NewCrashLog("log/crash.log"); err != nil {
+		fmt.Println(err)
+	}
+
+	log.InitLogger() // 로거 초기화
+
+	// 런타임 제어용 유닉스 도메인 소켓 서버 시작
+	server, err := control.NewServer(reloadConfig)
+	if err != nil {
+		log.LogWarn("failed to start control server: %s", err)
+	} else {
+		controlServer = server
+	}
 }
 
 // finalization 모듈 종료 시 작업 정리 함수
 func finalization() {
+	if controlServer != nil {
+		controlServer.Close() // 제어 소켓 서버 종료
+	}
+
 	log.FinalizeLog() // 로그 자원 정리
 }
 
@@ -243,8 +432,8 @@ func finalization() {
 // Parameters:
 //   - sigChan: 시그널을 수신할 채널
 func setupSignal(sigChan chan os.Signal) {
-	signal.Ignore(syscall.SIGABRT, syscall.SIGALRM, syscall.SIGHUP, syscall.SIGTSTP,
+	signal.Ignore(syscall.SIGABRT, syscall.SIGALRM, syscall.SIGTSTP,
 		syscall.SIGILL, syscall.SIGPROF, syscall.SIGQUIT, syscall.SIGVTALRM)
 
-	signal.Notify(sigChan, syscall.SIGTERM)
+	signal.Notify(sigChan, syscall.SIGTERM, syscall.SIGHUP)
 }
@@ -0,0 +1,40 @@
+//go:build linux
+
+// Copyright (c) 2024 Generic API Server All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"fmt"
+	"os"
+)
+
+// NewCrashLog 표준 에러(fd 2)를 지정된 파일로 복제하여 크래시 로그를 보존
+//
+// panic이나 Go 런타임 크래시(세그폴트 등)는 zap을 거치지 않고 raw stderr에
+// 직접 출력되므로, 데몬화 과정에서 표준 출력/에러가 /dev/null로 재지정되면
+// 그대로 유실된다. 데몬화(표준 입출력 재지정)가 끝난 뒤에 이 함수로 fd 2를 파일에 연결해 두는 것이
+// detached 데몬에서 세그폴트나 복구되지 않은 panic의 스택 트레이스를
+// 확인할 수 있는 유일한 방법이다.
+//
+// Parameters:
+//   - path: 크래시 로그를 기록할 파일 경로
+//
+// Returns:
+//   - error: 성공(nil), 실패(error)
+func NewCrashLog(path string) error {
+	crashFile, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0640)
+	if err != nil {
+		return fmt.Errorf("failed to open crash log file: %s", err)
+	}
+	defer crashFile.Close()
+
+	// fd 2(stderr)를 크래시 로그 파일로 복제
+	if err := dupFd(int(crashFile.Fd()), int(os.Stderr.Fd())); err != nil {
+		return fmt.Errorf("failed to redirect stderr to crash log: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+//go:build linux
+
+// Copyright (c) 2024 Generic API Server All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap/zapcore"
+)
+
+// contextKey context 부가 정보 조회에 사용하는 내부 키 타입(외부 패키지와의 충돌 방지)
+type contextKey int
+
+const (
+	// RequestIDKey 요청을 추적하기 위한 ID를 저장하는 context 키
+	RequestIDKey contextKey = iota
+	// UsernameKey 요청을 수행한 사용자명을 저장하는 context 키
+	UsernameKey
+	// UserIDKey 요청을 수행한 사용자 ID를 저장하는 context 키
+	UserIDKey
+)
+
+// LogInfoCtx context 정보(trace_id, span_id, 요청 정보)를 포함한 정보 로그 출력
+//
+// Parameters:
+//   - ctx: 요청 컨텍스트
+//   - format: 로그 포맷
+//   - args: 가변 인자
+func LogInfoCtx(ctx context.Context, format string, args ...interface{}) {
+	logWithCtx(ctx, zapcore.InfoLevel, format, args...)
+}
+
+// LogWarnCtx context 정보를 포함한 경고 로그 출력
+//
+// Parameters:
+//   - ctx: 요청 컨텍스트
+//   - format: 로그 포맷
+//   - args: 가변 인자
+func LogWarnCtx(ctx context.Context, format string, args ...interface{}) {
+	logWithCtx(ctx, zapcore.WarnLevel, format, args...)
+}
+
+// LogErrorCtx context 정보를 포함한 에러 로그 출력
+//
+// 활성 스팬이 있으면 span.RecordError와 span.SetStatus를 호출하여
+// 로그로 남긴 에러가 트레이스에도 함께 드러나도록 한다.
+//
+// Parameters:
+//   - ctx: 요청 컨텍스트
+//   - format: 로그 포맷
+//   - args: 가변 인자
+func LogErrorCtx(ctx context.Context, format string, args ...interface{}) {
+	logWithCtx(ctx, zapcore.ErrorLevel, format, args...)
+}
+
+// LogDebugCtx context 정보를 포함한 디버그 로그 출력
+//
+// Parameters:
+//   - ctx: 요청 컨텍스트
+//   - format: 로그 포맷
+//   - args: 가변 인자
+func LogDebugCtx(ctx context.Context, format string, args ...interface{}) {
+	logWithCtx(ctx, zapcore.DebugLevel, format, args...)
+}
+
+// logWithCtx 공통 로그 출력 처리
+//
+// Parameters:
+//   - ctx: 요청 컨텍스트
+//   - level: 로그 레벨
+//   - format: 로그 포맷
+//   - args: 가변 인자
+func logWithCtx(ctx context.Context, level zapcore.Level, format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+
+	l := logger.logger
+	if fields := ctxFields(ctx); len(fields) > 0 {
+		l = l.With(fields...)
+	}
+
+	switch level {
+	case zapcore.DebugLevel:
+		l.Debug(message)
+	case zapcore.WarnLevel:
+		l.Warn(message)
+	case zapcore.ErrorLevel:
+		l.Error(message)
+		recordSpanError(ctx, message)
+	default:
+		l.Info(message)
+	}
+}
+
+// ctxFields context에서 trace_id/span_id 및 요청 부가 정보를 zap 필드로 추출
+//
+// Parameters:
+//   - ctx: 요청 컨텍스트
+//
+// Returns:
+//   - []interface{}: logger.With에 전달할 키-값 쌍 목록
+func ctxFields(ctx context.Context) []interface{} {
+	var fields []interface{}
+
+	if span := trace.SpanFromContext(ctx); span.SpanContext().IsValid() {
+		sc := span.SpanContext()
+		fields = append(fields, "trace_id", sc.TraceID().String(), "span_id", sc.SpanID().String())
+	}
+
+	if v, ok := ctx.Value(RequestIDKey).(string); ok && v != "" {
+		fields = append(fields, "request_id", v)
+	}
+	if v, ok := ctx.Value(UsernameKey).(string); ok && v != "" {
+		fields = append(fields, "username", v)
+	}
+	if v, ok := ctx.Value(UserIDKey).(string); ok && v != "" {
+		fields = append(fields, "user_id", v)
+	}
+
+	return fields
+}
+
+// recordSpanError 활성 스팬이 있으면 에러 로그를 트레이스에도 기록
+//
+// Parameters:
+//   - ctx: 요청 컨텍스트
+//   - message: 에러 메시지
+func recordSpanError(ctx context.Context, message string) {
+	span := trace.SpanFromContext(ctx)
+	if !span.SpanContext().IsValid() {
+		return
+	}
+
+	span.RecordError(fmt.Errorf("%s", message))
+	span.SetStatus(codes.Error, message)
+}
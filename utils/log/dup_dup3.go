@@ -0,0 +1,21 @@
+//go:build linux && (arm64 || loong64 || riscv64)
+
+// Copyright (c) 2024 Generic API Server All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import "syscall"
+
+// dupFd oldfd를 newfd로 복제(Dup2 미지원 아키텍처는 Dup3 사용)
+//
+// Parameters:
+//   - oldfd: 원본 파일 디스크립터
+//   - newfd: 복제될 파일 디스크립터
+//
+// Returns:
+//   - error: 성공(nil), 실패(error)
+func dupFd(oldfd, newfd int) error {
+	return syscall.Dup3(oldfd, newfd, 0)
+}
@@ -10,6 +10,7 @@ Package log 로그 유틸 패키지
 package log
 
 import (
+	"context"
 	"fmt"
 	"openkms/config"
 	"openkms/utils/file"
@@ -26,22 +27,55 @@ type Logger struct {
 
 var logger Logger
 
+// atomicLevel 로그 레벨(SIGHUP 등으로 재설정 시 재시작 없이 반영)
+var atomicLevel = zap.NewAtomicLevelAt(zapcore.DebugLevel)
+
+// lumberjackLogger 로그 파일 로테이션 설정(Reload 시 필드를 직접 갱신)
+var lumberjackLogger *lumberjack.Logger
+
+// activeLokiCore 현재 동작중인 Loki 전송 코어(재초기화 시 이전 고루틴 정리용)
+var activeLokiCore *lokiCore
+
 // init 패키지 초기화
 func init() {
 	file.MakeDirectory("log") // log 디렉터리 생성
-	initLogger()              // 로거 초기화
+	initLogger(config.Get())  // 로거 초기화
+}
+
+// InitLogger 설정 정보를 반영하여 로거를 초기화
+func InitLogger() {
+	initLogger(config.Get())
 }
 
 // initLogger 로거 초기화
-func initLogger() {
+//
+// Parameters:
+//   - cfg: 설정 정보(nil이면 기본 로그 경로/로테이션 값과 debug 레벨 사용)
+func initLogger(cfg *config.Config) {
+	logFilePath := config.LogFilePath
+	maxSize, maxBackups, maxAge, compress := 100, 10, 30, true
+	level := zapcore.DebugLevel
+
+	if cfg != nil {
+		logFilePath = cfg.Log.FilePath
+		maxSize = cfg.Log.MaxSize
+		maxBackups = cfg.Log.MaxBackups
+		maxAge = cfg.Log.MaxAge
+		compress = cfg.Log.Compress
+		level = parseLevel(cfg.Log.Level)
+	}
+
 	// lumberjack 로테이션 설정
-	logWriter := zapcore.AddSync(&lumberjack.Logger{
-		Filename:   config.LogFilePath, // 로그 파일 경로
-		MaxSize:    100,                // 최대 크기(MB)
-		MaxBackups: 10,                 // 보관할 백업 파일 수
-		MaxAge:     30,                 // 보관할 최대 일수
-		Compress:   true,               // 압축 여부
-	})
+	lumberjackLogger = &lumberjack.Logger{
+		Filename:   logFilePath, // 로그 파일 경로
+		MaxSize:    maxSize,     // 최대 크기(MB)
+		MaxBackups: maxBackups,  // 보관할 백업 파일 수
+		MaxAge:     maxAge,      // 보관할 최대 일수
+		Compress:   compress,    // 압축 여부
+	}
+	logWriter := zapcore.AddSync(lumberjackLogger)
+
+	atomicLevel.SetLevel(level)
 
 	// 로그 출력 포맷 설정
 	encoderConfig := zapcore.EncoderConfig{
@@ -59,22 +93,98 @@ func initLogger() {
 		ConsoleSeparator: " ",                                                  // 로그 필드 구분자
 	}
 
-	// 코어 생성
-	core := zapcore.NewCore(
+	// 파일(lumberjack) 코어
+	fileCore := zapcore.NewCore(
 		zapcore.NewConsoleEncoder(encoderConfig), // human-readable 형식의 출력
 		logWriter,                                // lumberjack과 연동
-		zapcore.DebugLevel,                       // 로그 레벨 설정
+		atomicLevel,                              // 로그 레벨 설정(동적 변경 가능)
 	)
 
+	cores := []zapcore.Core{fileCore}
+
+	// 이전 Loki 코어가 동작중이면 먼저 정리
+	if activeLokiCore != nil {
+		activeLokiCore.Close()
+		activeLokiCore = nil
+	}
+
+	// Loki 전송이 활성화되어 있으면 Loki 코어를 추가로 연결
+	if cfg != nil && cfg.Log.LokiEnable {
+		activeLokiCore = newLokiCore(&cfg.Log, atomicLevel)
+		cores = append(cores, activeLokiCore)
+	}
+
+	core := zapcore.NewTee(cores...)
+
 	// <호출자 정보 추가>
-	// 1단계 스택 깊이 스킵
+	// 2단계 스택 깊이 스킵(LogXxx/LogXxxCtx 래퍼 + logWithCtx 경유)
 	// ERROR 레벨 이상에서 스택 트레이스 추가
-	zapLogger := zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1),
+	zapLogger := zap.New(core, zap.AddCaller(), zap.AddCallerSkip(2),
 		zap.AddStacktrace(zapcore.ErrorLevel))
 	// SugaredLogger로 변환하여 가변 인자 지원
 	logger.logger = zapLogger.Sugar()
 }
 
+// ReloadConfig 설정 변경(SIGHUP 등)을 재시작 없이 로거에 반영
+//
+// 로그 레벨과 로테이션 대상(파일 경로, 크기, 보관 정책)만 갱신하며,
+// 데몬을 재시작하지 않아도 즉시 적용된다.
+func ReloadConfig() {
+	cfg := config.Get()
+	if cfg == nil {
+		return
+	}
+
+	atomicLevel.SetLevel(parseLevel(cfg.Log.Level))
+
+	if lumberjackLogger != nil {
+		lumberjackLogger.Filename = cfg.Log.FilePath
+		lumberjackLogger.MaxSize = cfg.Log.MaxSize
+		lumberjackLogger.MaxBackups = cfg.Log.MaxBackups
+		lumberjackLogger.MaxAge = cfg.Log.MaxAge
+		lumberjackLogger.Compress = cfg.Log.Compress
+		// 열려 있는 파일을 닫아 다음 기록부터 변경된 경로로 다시 열리도록 함
+		lumberjackLogger.Close()
+	}
+}
+
+// SetLevel 로그 레벨을 런타임에 변경(재시작 불필요)
+//
+// Parameters:
+//   - level: 로그 레벨 문자열(debug, info, warn, error)
+//
+// Returns:
+//   - error: 성공(nil), 실패(error)
+func SetLevel(level string) error {
+	switch level {
+	case "debug", "info", "warn", "error":
+		atomicLevel.SetLevel(parseLevel(level))
+		return nil
+	default:
+		return fmt.Errorf("invalid log level: %s", level)
+	}
+}
+
+// parseLevel 설정 파일의 로그 레벨 문자열을 zapcore.Level로 변환
+//
+// Parameters:
+//   - level: 로그 레벨 문자열(debug, info, warn, error)
+//
+// Returns:
+//   - zapcore.Level: 변환된 로그 레벨(알 수 없는 값이면 InfoLevel)
+func parseLevel(level string) zapcore.Level {
+	switch level {
+	case "debug":
+		return zapcore.DebugLevel
+	case "warn":
+		return zapcore.WarnLevel
+	case "error":
+		return zapcore.ErrorLevel
+	default:
+		return zapcore.InfoLevel
+	}
+}
+
 // FinalizeLog 로그 자원 정리
 func FinalizeLog() {
 	logger.logger.Sync() // 프로그램 종료 시 남은 로그가 모두 기록되도록 함
@@ -91,40 +201,44 @@ func customCapitalLevelEncoder(level zapcore.Level, enc zapcore.PrimitiveArrayEn
 
 // LogInfo 정보 로그를 출력하는 함수 (가변 인자 처리)
 //
+// context가 없는 호출 지점을 위한 LogInfoCtx의 하위 호환용 래퍼.
+//
 // Parameters:
 //   - format: 로그 포맷
 //   - args: 가변 인자
 func LogInfo(format string, args ...interface{}) {
-	message := fmt.Sprintf(format, args...)
-	logger.logger.Info(message)
+	logWithCtx(context.Background(), zapcore.InfoLevel, format, args...)
 }
 
 // LogWarn 경고 로그를 출력하는 함수 (가변 인자 처리)
 //
+// context가 없는 호출 지점을 위한 LogWarnCtx의 하위 호환용 래퍼.
+//
 // Parameters:
 //   - format: 로그 포맷
 //   - args: 가변 인자
 func LogWarn(format string, args ...interface{}) {
-	message := fmt.Sprintf(format, args...)
-	logger.logger.Warn(message)
+	logWithCtx(context.Background(), zapcore.WarnLevel, format, args...)
 }
 
 // LogError 에러 로그를 출력하는 함수 (가변 인자 처리)
 //
+// context가 없는 호출 지점을 위한 LogErrorCtx의 하위 호환용 래퍼.
+//
 // Parameters:
 //   - format: 로그 포맷
 //   - args: 가변 인자
 func LogError(format string, args ...interface{}) {
-	message := fmt.Sprintf(format, args...)
-	logger.logger.Error(message)
+	logWithCtx(context.Background(), zapcore.ErrorLevel, format, args...)
 }
 
 // LogDebug 디버그 로그를 출력하는 함수 (가변 인자 처리)
 //
+// context가 없는 호출 지점을 위한 LogDebugCtx의 하위 호환용 래퍼.
+//
 // Parameters:
 //   - format: 로그 포맷
 //   - args: 가변 인자
 func LogDebug(format string, args ...interface{}) {
-	message := fmt.Sprintf(format, args...)
-	logger.logger.Debug(message)
+	logWithCtx(context.Background(), zapcore.DebugLevel, format, args...)
 }
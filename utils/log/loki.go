@@ -0,0 +1,314 @@
+//go:build linux
+
+// Copyright (c) 2024 Generic API Server All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"openkms/config"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	lokiBatchInterval  = 1 * time.Second        // 배치 전송 주기
+	lokiBatchSize      = 500                    // 배치 전송 기준 라인 수
+	lokiBufferSize     = 4096                   // 배치 대기 채널 버퍼 크기
+	lokiMaxRetries     = 5                      // 배치 전송 최대 시도 횟수(최초 전송 포함)
+	lokiInitialBackoff = 500 * time.Millisecond // 최초 재시도 대기 시간
+	lokiRequestTimeout = 10 * time.Second       // 배치 전송 HTTP 요청 타임아웃
+)
+
+// lokiEntry 배치 전송을 기다리는 로그 한 줄
+type lokiEntry struct {
+	timestamp string // UNIX 나노초 타임스탬프
+	line      string // 인코딩된 로그 메시지
+}
+
+// lokiPushRequest Loki Push API 요청 본문
+type lokiPushRequest struct {
+	Streams []lokiStream `json:"streams"`
+}
+
+// lokiStream Loki 스트림(레이블 + 로그 라인 목록)
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// lokiShared 동일 계통의 lokiCore(With()로 복제된 코어 포함)가 공유하는 상태
+//
+// With()는 로그 필드가 추가될 때마다(필드가 붙는 거의 모든 호출에서) 새
+// lokiCore 값을 만들어내므로, 배치 전송 채널/카운터/고루틴 동기화 객체는
+// 이 구조체에 모아 포인터로 공유하고 lokiCore는 인코더만 복제한다.
+type lokiShared struct {
+	httpClient *http.Client
+	url        string
+	labels     map[string]string
+
+	entryChan    chan lokiEntry
+	droppedCount atomic.Int64
+
+	wg   sync.WaitGroup
+	done chan struct{}
+}
+
+// lokiCore Loki Push API로 로그를 배치 전송하는 zapcore.Core 구현체
+//
+// 로그 호출이 네트워크 I/O로 인해 블로킹되지 않도록 버퍼 채널과 별도의
+// 배경 고루틴을 통해 비동기로 전송한다.
+type lokiCore struct {
+	zapcore.LevelEnabler
+	encoder zapcore.Encoder
+	shared  *lokiShared
+}
+
+// newLokiCore Loki 코어를 생성하고 배치 전송 고루틴을 시작
+//
+// Parameters:
+//   - cfg: 로그 설정 정보
+//   - level: 로그 레벨
+//
+// Returns:
+//   - *lokiCore: 생성된 Loki 코어
+func newLokiCore(cfg *config.LogConfig, level zapcore.LevelEnabler) *lokiCore {
+	encoderConfig := zapcore.EncoderConfig{
+		TimeKey:        "time",
+		LevelKey:       "level",
+		MessageKey:     "msg",
+		StacktraceKey:  "stacktrace",
+		LineEnding:     zapcore.DefaultLineEnding,
+		EncodeLevel:    zapcore.LowercaseLevelEncoder,
+		EncodeTime:     zapcore.ISO8601TimeEncoder,
+		EncodeDuration: zapcore.StringDurationEncoder,
+	}
+
+	shared := &lokiShared{
+		httpClient: &http.Client{Timeout: lokiRequestTimeout},
+		url:        fmt.Sprintf("http://%s:%d/loki/api/v1/push", cfg.LokiHost, cfg.LokiPort),
+		labels: map[string]string{
+			"job":    cfg.LokiJob,
+			"source": cfg.LokiSource,
+		},
+		entryChan: make(chan lokiEntry, lokiBufferSize),
+		done:      make(chan struct{}),
+	}
+
+	core := &lokiCore{
+		LevelEnabler: level,
+		encoder:      zapcore.NewJSONEncoder(encoderConfig),
+		shared:       shared,
+	}
+
+	shared.wg.Add(1)
+	go shared.run()
+
+	return core
+}
+
+// With 필드가 추가된 코어 복제본 반환(zapcore.Core 구현)
+//
+// 공유 상태(shared)는 포인터를 그대로 넘겨 재사용하고 인코더만 복제한다.
+// sync.WaitGroup/atomic.Int64를 lokiCore에 직접 두고 값 복사하면 함께 복제되어
+// 드롭 카운터가 어긋나고 WaitGroup이 미정의 동작을 일으키므로 shared에 둔다.
+func (c *lokiCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := &lokiCore{
+		LevelEnabler: c.LevelEnabler,
+		encoder:      c.encoder.Clone(),
+		shared:       c.shared,
+	}
+	for _, f := range fields {
+		f.AddTo(clone.encoder)
+	}
+
+	return clone
+}
+
+// Check 로그 레벨에 따라 이 코어를 기록 대상에 추가(zapcore.Core 구현)
+func (c *lokiCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+
+	return checked
+}
+
+// Write 로그 한 줄을 인코딩하여 배치 전송 채널에 적재(zapcore.Core 구현)
+func (c *lokiCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.encoder.EncodeEntry(entry, fields)
+	if err != nil {
+		return fmt.Errorf("failed to encode loki entry: %s", err)
+	}
+	line := buf.String()
+	buf.Free()
+
+	e := lokiEntry{
+		timestamp: strconv.FormatInt(entry.Time.UnixNano(), 10),
+		line:      line,
+	}
+
+	select {
+	case c.shared.entryChan <- e:
+	default:
+		// 버퍼가 가득 찼으면 가장 오래된 항목을 비우고 새 항목을 적재(drop-oldest)
+		select {
+		case <-c.shared.entryChan:
+			c.shared.droppedCount.Add(1)
+		default:
+		}
+
+		select {
+		case c.shared.entryChan <- e:
+		default:
+			c.shared.droppedCount.Add(1)
+		}
+	}
+
+	return nil
+}
+
+// Sync 아무 동작도 하지 않음(zapcore.Core 구현)
+//
+// 채널과 배치에 쌓인 로그는 여기서 전송되지 않으며, Close에서 플러시된다.
+func (c *lokiCore) Sync() error {
+	return nil
+}
+
+// Close 배치 전송 고루틴을 정상 종료(남은 로그는 플러시 후 종료)
+func (c *lokiCore) Close() {
+	close(c.shared.done)
+	c.shared.wg.Wait()
+}
+
+// DroppedCount 버퍼 초과, 인코딩 실패 또는 전송 실패로 드롭된 로그 라인 수 반환
+//
+// Returns:
+//   - int64: 드롭된 로그 라인 수
+func (c *lokiCore) DroppedCount() int64 {
+	return c.shared.droppedCount.Load()
+}
+
+// run 배치 전송 고루틴 본체(시간 또는 크기 기준으로 플러시)
+func (s *lokiShared) run() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(lokiBatchInterval)
+	defer ticker.Stop()
+
+	batch := make([]lokiEntry, 0, lokiBatchSize)
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		s.send(batch)
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case e := <-s.entryChan:
+			batch = append(batch, e)
+			if len(batch) >= lokiBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-s.done:
+			// 종료 전 채널에 남은 로그를 모두 비워 전송
+			for {
+				select {
+				case e := <-s.entryChan:
+					batch = append(batch, e)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// send 배치를 Loki Push API로 전송(실패 시 지수 백오프로 재시도)
+//
+// Parameters:
+//   - batch: 전송할 로그 라인 배치
+func (s *lokiShared) send(batch []lokiEntry) {
+	values := make([][2]string, 0, len(batch))
+	for _, e := range batch {
+		values = append(values, [2]string{e.timestamp, e.line})
+	}
+
+	body, err := json.Marshal(lokiPushRequest{
+		Streams: []lokiStream{
+			{
+				Stream: s.labels,
+				Values: values,
+			},
+		},
+	})
+	if err != nil {
+		s.droppedCount.Add(int64(len(batch)))
+		return
+	}
+
+	backoff := lokiInitialBackoff
+	for attempt := 0; attempt < lokiMaxRetries; attempt++ {
+		if s.post(body) {
+			return
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-s.done:
+			// 종료 처리 중이면 재시도 대기를 중단하고 즉시 드롭 처리로 넘어감
+			s.droppedCount.Add(int64(len(batch)))
+			return
+		}
+		backoff *= 2
+	}
+
+	// 재시도 후에도 실패하면 해당 배치는 드롭
+	s.droppedCount.Add(int64(len(batch)))
+}
+
+// post 배치 본문을 Loki Push API에 HTTP POST로 전송
+//
+// http.DefaultClient는 타임아웃이 없어 응답이 오지 않는 엔드포인트를 만나면
+// 배치 전송 고루틴이 영구히 블로킹될 수 있으므로, 타임아웃이 설정된 전용
+// 클라이언트와 컨텍스트로 요청을 전송한다.
+//
+// Parameters:
+//   - body: 전송할 요청 본문
+//
+// Returns:
+//   - bool: 전송 성공 여부
+func (s *lokiShared) post(body []byte) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), lokiRequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode < 300
+}
@@ -10,59 +10,286 @@ Package process 프로세스 유틸 패키지
 package process
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"strconv"
+	"strings"
 	"syscall"
 )
 
-// IsProcessRunning 프로세스 동작 여부 확인
+// envVarName 데몬 컨텍스트 초기화 여부를 자식 프로세스에 전달하는 환경 변수명
+const envVarName = "OPENKMS_DAEMON_CONTEXT"
+
+// ErrAlreadyRunning PID 파일 잠금 획득 실패(이미 동작 중인 프로세스 존재) 에러
+var ErrAlreadyRunning = errors.New("another instance of the process is already running")
+
+// Context 데몬 프로세스 실행 컨텍스트 구조체
+type Context struct {
+	PidFileName string              // PID 파일 경로
+	PidFilePerm os.FileMode         // PID 파일 권한(0이면 0644 사용)
+	LogFileName string              // 표준 출력/에러를 리다이렉션할 로그 파일 경로(비어있으면 /dev/null)
+	LogFilePerm os.FileMode         // 로그 파일 권한(0이면 0640 사용)
+	WorkDir     string              // 자식 프로세스의 작업 디렉터리(비어있으면 변경하지 않음)
+	Chroot      string              // chroot 디렉터리(비어있으면 chroot하지 않음)
+	Umask       int                 // 파일 생성 마스크
+	Credential  *syscall.Credential // 권한을 낮출 사용자/그룹 정보(nil이면 변경하지 않음)
+	Args        []string            // 자식 프로세스에 전달할 인자(nil이면 os.Args[1:] 사용)
+	Env         []string            // 자식 프로세스에 추가로 전달할 환경 변수
+
+	pidFile *os.File // 잠금을 보유한 PID 파일 핸들(자식 프로세스에서만 유효)
+}
+
+// Reborn 프로세스를 데몬화
 //
-// Parameters:
-//   - pid: PID
+// 부모 프로세스에서 호출하면 PID 파일을 잠그고 자식(데몬) 프로세스를 생성한다.
+// 자식 프로세스에서 호출하면 부모로부터 상속받은 PID 파일로 데몬 환경을 구성한다.
 //
 // Returns:
-//   - bool: 동작중(true), 미동작(false)
-func IsProcessRunning(pid int) bool {
-	// 프로세스가 존재하는지 확인
-	process, err := os.FindProcess(pid)
-	if err != nil {
-		return false
+//   - *os.Process: 부모 프로세스에서는 생성된 자식 프로세스 정보, 자식 프로세스에서는 nil
+//   - error: 성공(nil), 실패(error)
+func (c *Context) Reborn() (*os.Process, error) {
+	if os.Getenv(envVarName) == "" {
+		return c.parent()
 	}
 
-	// 시그널 0을 보내 실제로 프로세스가 동작중인지 확인
-	err = process.Signal(syscall.Signal(0))
-	return err == nil
+	return nil, c.child()
 }
 
-// Daemonize 프로세스 데몬화
+// parent PID 파일을 잠그고 자식(데몬) 프로세스를 생성
 //
-// Return:
+// Returns:
+//   - *os.Process: 생성된 자식 프로세스 정보
 //   - error: 성공(nil), 실패(error)
-func Daemonize() error {
-	// 실행 파일 경로 획득
+func (c *Context) parent() (*os.Process, error) {
+	pidFile, err := c.openPidFile()
+	if err != nil {
+		return nil, err
+	}
+
+	// flock으로 PID 파일을 배타적으로 잠궈 중복 실행을 방지
+	if err := syscall.Flock(int(pidFile.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		pidFile.Close()
+		return nil, ErrAlreadyRunning
+	}
+
 	exePath, err := os.Executable()
 	if err != nil {
-		return fmt.Errorf("error getting executable path: %s", err)
+		pidFile.Close()
+		return nil, fmt.Errorf("error getting executable path: %s", err)
 	}
 
-	// 자식 프로세스 생성
-	cmd := exec.Command(exePath, os.Args[1:]...)
-	cmd.Env = append(os.Environ(), "DAEMON=true") // 환경 변수 추가
+	args := c.Args
+	if args == nil {
+		args = os.Args[1:]
+	}
+
+	cmd := exec.Command(exePath, args...)
+	cmd.Env = append(append(os.Environ(), c.Env...), envVarName+"=1")
 	cmd.Stdin = nil
 	cmd.Stdout = nil
 	cmd.Stderr = nil
+	// 잠긴 PID 파일 fd를 자식 프로세스에 fd 3(ExtraFiles[0])으로 전달
+	cmd.ExtraFiles = []*os.File{pidFile}
 
 	// 새로운 세션을 생성하고 부모 프로세스와 분리
 	cmd.SysProcAttr = &syscall.SysProcAttr{
 		Setsid: true,
 	}
 
-	// 자식 프로세스 실행
 	if err := cmd.Start(); err != nil {
-		return fmt.Errorf("failed to start process: %s", err)
+		syscall.Flock(int(pidFile.Fd()), syscall.LOCK_UN)
+		pidFile.Close()
+		return nil, fmt.Errorf("failed to start process: %s", err)
+	}
+
+	// 자식 프로세스가 동일한 open file description을 상속받으므로
+	// 부모의 fd를 닫아도 flock은 유지된다
+	pidFile.Close()
+
+	return cmd.Process, nil
+}
+
+// child 부모로부터 상속받은 PID 파일로 데몬 환경을 구성
+//
+// Returns:
+//   - error: 성공(nil), 실패(error)
+func (c *Context) child() error {
+	// 부모로부터 상속받은 PID 파일(fd 3) 획득
+	pidFile := os.NewFile(3, c.PidFileName)
+	if pidFile == nil {
+		return fmt.Errorf("failed to get inherited pid file")
+	}
+	c.pidFile = pidFile
+
+	if err := pidFile.Truncate(0); err != nil {
+		return fmt.Errorf("failed to truncate pid file: %s", err)
+	}
+	if _, err := pidFile.WriteAt([]byte(strconv.Itoa(os.Getpid())), 0); err != nil {
+		return fmt.Errorf("failed to write pid file: %s", err)
+	}
+
+	if c.Umask != 0 {
+		syscall.Umask(c.Umask)
+	}
+
+	if c.Chroot != "" {
+		if err := syscall.Chroot(c.Chroot); err != nil {
+			return fmt.Errorf("failed to chroot: %s", err)
+		}
+	}
+
+	if c.WorkDir != "" {
+		if err := syscall.Chdir(c.WorkDir); err != nil {
+			return fmt.Errorf("failed to change working directory: %s", err)
+		}
+	}
+
+	if c.Credential != nil {
+		// 부모(대개 root)로부터 물려받은 보조 그룹을 먼저 비워야 그룹 기반 권한이
+		// 실제로 내려간다. Setgid/Setuid만으로는 보조 그룹이 그대로 남는다.
+		if err := syscall.Setgroups([]int{int(c.Credential.Gid)}); err != nil {
+			return fmt.Errorf("failed to setgroups: %s", err)
+		}
+		if err := syscall.Setgid(int(c.Credential.Gid)); err != nil {
+			return fmt.Errorf("failed to setgid: %s", err)
+		}
+		if err := syscall.Setuid(int(c.Credential.Uid)); err != nil {
+			return fmt.Errorf("failed to setuid: %s", err)
+		}
+	}
+
+	if err := c.redirectStdFiles(); err != nil {
+		return err
 	}
 
-	os.Exit(0) // 부모 프로세스 종료
 	return nil
 }
+
+// redirectStdFiles 표준 입출력을 /dev/null 또는 설정된 로그 파일로 재지정
+//
+// Returns:
+//   - error: 성공(nil), 실패(error)
+func (c *Context) redirectStdFiles() error {
+	devNull, err := os.OpenFile(os.DevNull, os.O_RDWR, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %s", os.DevNull, err)
+	}
+	defer devNull.Close()
+
+	if err := dupFd(int(devNull.Fd()), int(os.Stdin.Fd())); err != nil {
+		return fmt.Errorf("failed to redirect stdin: %s", err)
+	}
+
+	// 로그 파일이 설정되지 않았으면 표준 출력/에러도 /dev/null로 보낸다
+	logFd := int(devNull.Fd())
+	if c.LogFileName != "" {
+		perm := c.LogFilePerm
+		if perm == 0 {
+			perm = 0640
+		}
+
+		logFile, err := os.OpenFile(c.LogFileName, os.O_WRONLY|os.O_CREATE|os.O_APPEND, perm)
+		if err != nil {
+			return fmt.Errorf("failed to open log file: %s", err)
+		}
+		defer logFile.Close()
+
+		logFd = int(logFile.Fd())
+	}
+
+	if err := dupFd(logFd, int(os.Stdout.Fd())); err != nil {
+		return fmt.Errorf("failed to redirect stdout: %s", err)
+	}
+	if err := dupFd(logFd, int(os.Stderr.Fd())); err != nil {
+		return fmt.Errorf("failed to redirect stderr: %s", err)
+	}
+
+	return nil
+}
+
+// openPidFile PID 파일 열기(없으면 생성)
+//
+// Returns:
+//   - *os.File: PID 파일 핸들
+//   - error: 성공(nil), 실패(error)
+func (c *Context) openPidFile() (*os.File, error) {
+	perm := c.PidFilePerm
+	if perm == 0 {
+		perm = 0644
+	}
+
+	pidFile, err := os.OpenFile(c.PidFileName, os.O_CREATE|os.O_RDWR, perm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open pid file: %s", err)
+	}
+
+	return pidFile, nil
+}
+
+// Release PID 파일 잠금 해제 및 삭제
+//
+// Returns:
+//   - error: 성공(nil), 실패(error)
+func (c *Context) Release() error {
+	if c.pidFile == nil {
+		return nil
+	}
+
+	if err := syscall.Flock(int(c.pidFile.Fd()), syscall.LOCK_UN); err != nil {
+		c.pidFile.Close()
+		return fmt.Errorf("failed to unlock pid file: %s", err)
+	}
+
+	if err := c.pidFile.Close(); err != nil {
+		return fmt.Errorf("failed to close pid file: %s", err)
+	}
+
+	if err := os.Remove(c.PidFileName); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove pid file: %s", err)
+	}
+
+	return nil
+}
+
+// ReadPidFile PID 파일에서 PID 값 읽기
+//
+// Parameters:
+//   - pidFileName: PID 파일 경로
+//
+// Returns:
+//   - int: PID
+//   - error: 성공(nil), 실패(error)
+func ReadPidFile(pidFileName string) (int, error) {
+	data, err := os.ReadFile(pidFileName)
+	if err != nil {
+		return 0, err
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, fmt.Errorf("invalid pid file content: %s", err)
+	}
+
+	return pid, nil
+}
+
+// IsProcessRunning 프로세스 동작 여부 확인
+//
+// Parameters:
+//   - pid: PID
+//
+// Returns:
+//   - bool: 동작중(true), 미동작(false)
+func IsProcessRunning(pid int) bool {
+	// 프로세스가 존재하는지 확인
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+
+	// 시그널 0을 보내 실제로 프로세스가 동작중인지 확인
+	err = process.Signal(syscall.Signal(0))
+	return err == nil
+}